@@ -0,0 +1,130 @@
+package docker
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/docker/docker/cliconfig"
+	"github.com/docker/docker/registry"
+)
+
+// AuthProvider yields an ordered list of credential candidates to try
+// against a registry for repoInfo. pull tries each candidate in turn,
+// falling through to the next on a 401/403, so a Context can combine
+// several credential sources (cliconfig, environment, credential helpers)
+// instead of relying on a single static lookup.
+type AuthProvider interface {
+	AuthConfigs(repoInfo *registry.RepositoryInfo) ([]cliconfig.AuthConfig, error)
+}
+
+// cliConfigAuthProvider resolves credentials from the user's
+// ~/.docker/config.json, matching the original single-source behavior.
+type cliConfigAuthProvider struct {
+	configFile *cliconfig.ConfigFile
+}
+
+func (p *cliConfigAuthProvider) AuthConfigs(repoInfo *registry.RepositoryInfo) ([]cliconfig.AuthConfig, error) {
+	if p.configFile == nil || repoInfo == nil || repoInfo.Index == nil {
+		return nil, nil
+	}
+
+	return []cliconfig.AuthConfig{registry.ResolveAuthConfig(p.configFile, repoInfo.Index)}, nil
+}
+
+// envAuthProvider reads a single AuthConfig out of DOCKER_AUTH_CONFIG, a
+// JSON-encoded cliconfig.AuthConfig, so CI environments can inject
+// credentials without a config file on disk.
+type envAuthProvider struct{}
+
+func (envAuthProvider) AuthConfigs(repoInfo *registry.RepositoryInfo) ([]cliconfig.AuthConfig, error) {
+	raw := os.Getenv("DOCKER_AUTH_CONFIG")
+	if raw == "" {
+		return nil, nil
+	}
+
+	var authConfig cliconfig.AuthConfig
+	if err := json.Unmarshal([]byte(raw), &authConfig); err != nil {
+		return nil, fmt.Errorf("parsing DOCKER_AUTH_CONFIG: %v", err)
+	}
+
+	return []cliconfig.AuthConfig{authConfig}, nil
+}
+
+// credHelperAuthProvider invokes docker-credential-<name> over stdio using
+// the credential-helper protocol, so users can keep secrets in an external
+// store (keychain, vault, ...) instead of a plaintext cliconfig.
+type credHelperAuthProvider struct {
+	name string
+}
+
+type credHelperOutput struct {
+	ServerURL string
+	Username  string
+	Secret    string
+}
+
+func (p *credHelperAuthProvider) AuthConfigs(repoInfo *registry.RepositoryInfo) ([]cliconfig.AuthConfig, error) {
+	if repoInfo == nil || repoInfo.Index == nil {
+		return nil, nil
+	}
+
+	cmd := exec.Command("docker-credential-"+p.name, "get")
+	cmd.Stdin = strings.NewReader(repoInfo.Index.Name)
+
+	var out bytes.Buffer
+	cmd.Stdout = &out
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("docker-credential-%s get: %v", p.name, err)
+	}
+
+	var result credHelperOutput
+	if err := json.Unmarshal(out.Bytes(), &result); err != nil {
+		return nil, fmt.Errorf("docker-credential-%s: %v", p.name, err)
+	}
+
+	return []cliconfig.AuthConfig{{
+		Username: result.Username,
+		Password: result.Secret,
+	}}, nil
+}
+
+// multiAuthProvider chains several providers, concatenating every
+// candidate list in order and skipping providers that fail outright.
+type multiAuthProvider []AuthProvider
+
+func (providers multiAuthProvider) AuthConfigs(repoInfo *registry.RepositoryInfo) ([]cliconfig.AuthConfig, error) {
+	var candidates []cliconfig.AuthConfig
+	for _, p := range providers {
+		configs, err := p.AuthConfigs(repoInfo)
+		if err != nil {
+			logrus.Debugf("auth provider skipped: %v", err)
+			continue
+		}
+		candidates = append(candidates, configs...)
+	}
+
+	return candidates, nil
+}
+
+// authProvider returns the context's configured AuthProvider, or the
+// default cliconfig+env+credential-helper chain when none was set.
+func (c *Container) authProvider() AuthProvider {
+	if c.service.context.AuthProvider != nil {
+		return c.service.context.AuthProvider
+	}
+
+	providers := multiAuthProvider{
+		&cliConfigAuthProvider{configFile: c.service.context.ConfigFile},
+		envAuthProvider{},
+	}
+	for _, name := range c.service.context.CredentialHelpers {
+		providers = append(providers, &credHelperAuthProvider{name: name})
+	}
+
+	return providers
+}
@@ -0,0 +1,74 @@
+package docker
+
+import (
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/docker/docker/cliconfig"
+	"github.com/docker/docker/registry"
+)
+
+type fakeAuthProvider struct {
+	configs []cliconfig.AuthConfig
+	err     error
+}
+
+func (f *fakeAuthProvider) AuthConfigs(repoInfo *registry.RepositoryInfo) ([]cliconfig.AuthConfig, error) {
+	return f.configs, f.err
+}
+
+func TestMultiAuthProviderChainsAndSkipsFailures(t *testing.T) {
+	providers := multiAuthProvider{
+		&fakeAuthProvider{err: errors.New("boom")},
+		&fakeAuthProvider{configs: []cliconfig.AuthConfig{{Username: "a"}}},
+		&fakeAuthProvider{configs: []cliconfig.AuthConfig{{Username: "b"}, {Username: "c"}}},
+	}
+
+	got, err := providers.AuthConfigs(nil)
+	if err != nil {
+		t.Fatalf("AuthConfigs: %v", err)
+	}
+
+	want := []string{"a", "b", "c"}
+	if len(got) != len(want) {
+		t.Fatalf("got %d candidates, want %d: %#v", len(got), len(want), got)
+	}
+	for i, u := range want {
+		if got[i].Username != u {
+			t.Errorf("candidate %d = %q, want %q", i, got[i].Username, u)
+		}
+	}
+}
+
+func TestMultiAuthProviderEmpty(t *testing.T) {
+	got, err := (multiAuthProvider{}).AuthConfigs(nil)
+	if err != nil {
+		t.Fatalf("AuthConfigs: %v", err)
+	}
+	if len(got) != 0 {
+		t.Fatalf("got %#v, want empty", got)
+	}
+}
+
+func TestEnvAuthProviderParsesJSON(t *testing.T) {
+	os.Setenv("DOCKER_AUTH_CONFIG", `{"username":"u","password":"p"}`)
+	defer os.Unsetenv("DOCKER_AUTH_CONFIG")
+
+	configs, err := (envAuthProvider{}).AuthConfigs(nil)
+	if err != nil {
+		t.Fatalf("AuthConfigs: %v", err)
+	}
+	if len(configs) != 1 || configs[0].Username != "u" || configs[0].Password != "p" {
+		t.Fatalf("got %#v", configs)
+	}
+}
+
+func TestEnvAuthProviderEmptyWhenUnset(t *testing.T) {
+	os.Unsetenv("DOCKER_AUTH_CONFIG")
+
+	configs, err := (envAuthProvider{}).AuthConfigs(nil)
+	if err != nil || len(configs) != 0 {
+		t.Fatalf("got %#v, %v, want empty/no error", configs, err)
+	}
+}
@@ -36,6 +36,10 @@ func (c *Container) findExisting() (*dockerclient.Container, error) {
 }
 
 func (c *Container) Create() (*dockerclient.Container, error) {
+	if err := c.ensureImage(c.service.serviceConfig.Image); err != nil {
+		return nil, err
+	}
+
 	container, err := c.findExisting()
 	if err != nil {
 		return nil, err
@@ -139,8 +143,10 @@ func (c *Container) createContainer() (*dockerclient.Container, error) {
 	logrus.Debugf("Creating container %s %#v", c.name, config)
 
 	_, err = c.service.context.Client.CreateContainer(config, c.name)
-	if err != nil && err.Error() == "Not found" {
-		err = c.pull(config.Image)
+	if err != nil && IsErrImageNotFound(err) {
+		if err = c.pull(config.Image); err == nil {
+			_, err = c.service.context.Client.CreateContainer(config, c.name)
+		}
 	}
 
 	if err != nil {
@@ -169,6 +175,16 @@ func (c *Container) populateAdditionalHostConfig(hostConfig *dockerclient.HostCo
 			return err
 		}
 
+		for _, container := range containers {
+			id, err := container.Id()
+			if err != nil {
+				return err
+			}
+			if err := c.waitForReady(link.Target, id); err != nil {
+				return err
+			}
+		}
+
 		if link.Type == project.REL_TYPE_LINK {
 			c.addLinks(links, service, link, containers)
 		} else if link.Type == project.REL_TYPE_IPC_NAMESPACE {
@@ -307,20 +323,49 @@ func (c *Container) pull(image string) error {
 		return err
 	}
 
-	authConfig := cliconfig.AuthConfig{}
-	if c.service.context.ConfigFile != nil && repoInfo != nil && repoInfo.Index != nil {
-		authConfig = registry.ResolveAuthConfig(c.service.context.ConfigFile, repoInfo.Index)
+	candidates, err := c.authProvider().AuthConfigs(repoInfo)
+	if err != nil {
+		return err
+	}
+	if len(candidates) == 0 {
+		candidates = []cliconfig.AuthConfig{{}}
 	}
 
-	err = c.service.context.Client.PullImage(image, &dockerclient.AuthConfig{
-		Username: authConfig.Username,
-		Password: authConfig.Password,
-		Email:    authConfig.Email,
-	})
+	l := c.service.context.LoggerFactory.Create(c.name)
+	progress := NewProgressWriter(l, loggerIsTTY(l), c.service.context.PullOutput)
+	defer progress.Close()
+
+	var attempts []string
+	for _, authConfig := range candidates {
+		err = c.service.context.Client.PullImage(image, &dockerclient.AuthConfig{
+			Username: authConfig.Username,
+			Password: authConfig.Password,
+			Email:    authConfig.Email,
+		}, progress)
+		if err == nil {
+			return nil
+		}
 
-	if err != nil {
-		logrus.Errorf("Failed to pull image %s: %v", image, err)
+		attempts = append(attempts, err.Error())
+		if !isErrUnauthorized(err) {
+			break
+		}
 	}
 
+	err = fmt.Errorf("Failed to pull image %s after %d credential attempt(s): %s", image, len(attempts), strings.Join(attempts, "; "))
+	logrus.Errorf("%v", err)
 	return err
 }
+
+// resolveAuthConfig returns the first auth candidate the context's
+// AuthProvider offers for repoInfo, or a zero-value AuthConfig when none
+// apply. It exists for callers that only need a single credential, such as
+// resolving a remote manifest digest.
+func (c *Container) resolveAuthConfig(repoInfo *registry.RepositoryInfo) cliconfig.AuthConfig {
+	candidates, err := c.authProvider().AuthConfigs(repoInfo)
+	if err != nil || len(candidates) == 0 {
+		return cliconfig.AuthConfig{}
+	}
+
+	return candidates[0]
+}
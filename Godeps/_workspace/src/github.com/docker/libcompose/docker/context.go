@@ -0,0 +1,49 @@
+package docker
+
+import (
+	"time"
+
+	"github.com/docker/docker/cliconfig"
+	"github.com/docker/libcompose/logger"
+	"github.com/docker/libcompose/project"
+	"github.com/samalba/dockerclient"
+)
+
+// Context carries the daemon connection and shared configuration that
+// every Service and Container in a Project is built against.
+type Context struct {
+	Client        dockerclient.Client
+	ConfigFile    *cliconfig.ConfigFile
+	LoggerFactory logger.Factory
+	Project       *project.Project
+	Log           bool
+	Timeout       int
+
+	// PullPolicy controls when Create/Up pull an image rather than
+	// reusing whatever is already present on the daemon. The zero value
+	// ("") is treated as PullPolicyMissing.
+	PullPolicy PullPolicy
+
+	// AuthProvider supplies registry credentials for pull, trying each
+	// candidate it returns in order. When nil, pull falls back to a
+	// default chain of ConfigFile, DOCKER_AUTH_CONFIG, and
+	// CredentialHelpers.
+	AuthProvider AuthProvider
+	// CredentialHelpers names docker-credential-<name> helpers to
+	// consult, in order, as part of the default AuthProvider chain.
+	CredentialHelpers []string
+
+	// PullOutput, if set, is called with every decoded pull/build
+	// progress event, letting callers embedding libcompose observe raw
+	// progress programmatically in addition to the logger output.
+	PullOutput func(ProgressEvent)
+
+	// DependencyReadiness controls how long populateAdditionalHostConfig
+	// waits on a dependent service's container before wiring
+	// links/IPC/net namespaces to it. The zero value ("") is treated as
+	// DependencyReadinessNone, preserving the historical behavior.
+	DependencyReadiness DependencyReadiness
+	// DependencyReadinessTimeout bounds how long to wait for
+	// DependencyReadiness. Zero means defaultReadinessTimeout.
+	DependencyReadinessTimeout time.Duration
+}
@@ -0,0 +1,55 @@
+package docker
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/samalba/dockerclient"
+)
+
+// statusCodeError is implemented by dockerclient errors that carry the
+// HTTP status code returned by the daemon.
+type statusCodeError interface {
+	StatusCode() int
+}
+
+// IsErrImageNotFound reports whether err indicates that the Docker daemon
+// could not find the requested image, as opposed to some other failure
+// (auth, network, daemon down, ...). It is exported so createContainer's
+// pull-and-retry path, and any other caller needing the same check, don't
+// have to re-implement fragile string matches.
+func IsErrImageNotFound(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	if err == dockerclient.ErrNotFound {
+		return true
+	}
+
+	if sc, ok := err.(statusCodeError); ok {
+		return sc.StatusCode() == http.StatusNotFound
+	}
+
+	// Older dockerclient releases surface "no such image" failures as
+	// plain strings with no typed wrapper, so fall back to matching the
+	// messages the daemon is known to return.
+	msg := strings.ToLower(err.Error())
+	return strings.Contains(msg, "no such image") || strings.Contains(msg, "not found")
+}
+
+// isErrUnauthorized reports whether err represents a registry
+// authentication/authorization failure (HTTP 401 or 403). pull treats this
+// as a signal to try the next auth candidate rather than giving up.
+func isErrUnauthorized(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	if sc, ok := err.(statusCodeError); ok {
+		return sc.StatusCode() == http.StatusUnauthorized || sc.StatusCode() == http.StatusForbidden
+	}
+
+	msg := strings.ToLower(err.Error())
+	return strings.Contains(msg, "unauthorized") || strings.Contains(msg, "401") || strings.Contains(msg, "403")
+}
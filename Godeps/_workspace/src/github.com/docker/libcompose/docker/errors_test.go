@@ -0,0 +1,58 @@
+package docker
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/samalba/dockerclient"
+)
+
+type fakeStatusError struct {
+	code int
+}
+
+func (e *fakeStatusError) Error() string   { return "fake status error" }
+func (e *fakeStatusError) StatusCode() int { return e.code }
+
+func TestIsErrImageNotFound(t *testing.T) {
+	cases := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"nil", nil, false},
+		{"sentinel", dockerclient.ErrNotFound, true},
+		{"status 404", &fakeStatusError{code: 404}, true},
+		{"status 500", &fakeStatusError{code: 500}, false},
+		{"string fallback no such image", errors.New("Error: No such image: foo"), true},
+		{"string fallback not found", errors.New("Not found"), true},
+		{"unrelated", errors.New("connection refused"), false},
+	}
+
+	for _, c := range cases {
+		if got := IsErrImageNotFound(c.err); got != c.want {
+			t.Errorf("%s: IsErrImageNotFound(%v) = %v, want %v", c.name, c.err, got, c.want)
+		}
+	}
+}
+
+func TestIsErrUnauthorized(t *testing.T) {
+	cases := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"nil", nil, false},
+		{"status 401", &fakeStatusError{code: 401}, true},
+		{"status 403", &fakeStatusError{code: 403}, true},
+		{"status 404", &fakeStatusError{code: 404}, false},
+		{"string fallback", errors.New("unauthorized: incorrect username or password"), true},
+		{"unrelated", errors.New("connection refused"), false},
+	}
+
+	for _, c := range cases {
+		if got := isErrUnauthorized(c.err); got != c.want {
+			t.Errorf("%s: isErrUnauthorized(%v) = %v, want %v", c.name, c.err, got, c.want)
+		}
+	}
+}
@@ -0,0 +1,188 @@
+package docker
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/samalba/dockerclient"
+	"golang.org/x/net/context"
+)
+
+// EventType identifies the kind of container lifecycle event delivered by
+// Container.Events, translated from the daemon's raw event status string.
+type EventType string
+
+const (
+	EventCreated      EventType = "created"
+	EventStarted      EventType = "started"
+	EventDied         EventType = "died"
+	EventOOMKilled    EventType = "oom_killed"
+	EventHealthStatus EventType = "health_status"
+)
+
+// Event is a typed, already-filtered notification derived from the Docker
+// daemon's /events stream for a single compose-managed container.
+type Event struct {
+	Type      EventType
+	Container string
+	Raw       *dockerclient.Event
+}
+
+// eventHub owns the single /events connection for a Context and fans
+// decoded events out to whichever containers have subscribed, so N
+// services watching events doesn't mean N daemon connections. It is
+// reference-counted: once its last subscriber unsubscribes, it stops
+// monitoring and removes itself from eventHubs instead of leaking a live
+// connection for the life of the process.
+type eventHub struct {
+	client dockerclient.Client
+	ctx    *Context
+
+	mu          sync.Mutex
+	subscribers map[string][]chan Event
+	refs        int
+	started     bool
+}
+
+var (
+	eventHubsMu sync.Mutex
+	eventHubs   = map[*Context]*eventHub{}
+)
+
+func hubFor(ctx *Context) *eventHub {
+	eventHubsMu.Lock()
+	defer eventHubsMu.Unlock()
+
+	hub, ok := eventHubs[ctx]
+	if !ok {
+		hub = &eventHub{client: ctx.Client, ctx: ctx, subscribers: map[string][]chan Event{}}
+		eventHubs[ctx] = hub
+	}
+
+	return hub
+}
+
+func (h *eventHub) ensureStarted() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.started {
+		return
+	}
+	h.started = true
+
+	h.client.StartMonitorEvents(h.handle, nil)
+}
+
+// release stops monitoring and drops h from eventHubs once its last
+// subscriber has gone away.
+func (h *eventHub) release() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.refs > 0 || !h.started {
+		return
+	}
+
+	h.started = false
+	h.client.StopAllMonitorEvents()
+
+	eventHubsMu.Lock()
+	if eventHubs[h.ctx] == h {
+		delete(eventHubs, h.ctx)
+	}
+	eventHubsMu.Unlock()
+}
+
+func (h *eventHub) handle(raw *dockerclient.Event, ec chan error, args ...interface{}) {
+	event, ok := classifyEvent(raw)
+	if !ok {
+		return
+	}
+
+	h.mu.Lock()
+	subs := append([]chan Event{}, h.subscribers[raw.Id]...)
+	h.mu.Unlock()
+
+	for _, ch := range subs {
+		select {
+		case ch <- event:
+		default:
+			// Subscriber isn't keeping up; drop rather than block the
+			// single shared connection for every other container.
+		}
+	}
+}
+
+func classifyEvent(raw *dockerclient.Event) (Event, bool) {
+	var t EventType
+	switch {
+	case raw.Status == "create":
+		t = EventCreated
+	case raw.Status == "start":
+		t = EventStarted
+	case raw.Status == "die":
+		t = EventDied
+	case raw.Status == "oom":
+		t = EventOOMKilled
+	case strings.HasPrefix(raw.Status, "health_status:"):
+		t = EventHealthStatus
+	default:
+		return Event{}, false
+	}
+
+	return Event{Type: t, Container: raw.Id, Raw: raw}, true
+}
+
+func (h *eventHub) subscribe(containerID string) (chan Event, func()) {
+	ch := make(chan Event, 16)
+
+	h.mu.Lock()
+	h.subscribers[containerID] = append(h.subscribers[containerID], ch)
+	h.refs++
+	h.mu.Unlock()
+
+	h.ensureStarted()
+
+	cancel := func() {
+		h.mu.Lock()
+		subs := h.subscribers[containerID]
+		for i, c := range subs {
+			if c == ch {
+				h.subscribers[containerID] = append(subs[:i], subs[i+1:]...)
+				h.refs--
+				break
+			}
+		}
+		close(ch)
+		h.mu.Unlock()
+
+		h.release()
+	}
+
+	return ch, cancel
+}
+
+// Events subscribes to the daemon's event stream for this container,
+// already filtered down to events for the container's own id, and
+// delivers typed lifecycle events until ctx is done. It shares a single
+// events connection per Context rather than opening a new one per call.
+func (c *Container) Events(ctx context.Context) (<-chan Event, error) {
+	container, err := c.findExisting()
+	if err != nil {
+		return nil, err
+	}
+	if container == nil {
+		return nil, fmt.Errorf("container %s does not exist", c.name)
+	}
+
+	ch, cancel := hubFor(c.service.context).subscribe(container.Id)
+
+	go func() {
+		<-ctx.Done()
+		cancel()
+	}()
+
+	return ch, nil
+}
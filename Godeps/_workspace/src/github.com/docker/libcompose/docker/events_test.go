@@ -0,0 +1,83 @@
+package docker
+
+import (
+	"testing"
+
+	"github.com/samalba/dockerclient"
+)
+
+func TestClassifyEvent(t *testing.T) {
+	cases := []struct {
+		name   string
+		status string
+		wantOK bool
+		wantT  EventType
+	}{
+		{"create", "create", true, EventCreated},
+		{"start", "start", true, EventStarted},
+		{"die", "die", true, EventDied},
+		{"oom", "oom", true, EventOOMKilled},
+		{"health_status", "health_status: healthy", true, EventHealthStatus},
+		{"unrelated", "exec_create", false, ""},
+	}
+
+	for _, c := range cases {
+		event, ok := classifyEvent(&dockerclient.Event{Status: c.status, Id: "abc"})
+		if ok != c.wantOK {
+			t.Errorf("%s: ok = %v, want %v", c.name, ok, c.wantOK)
+			continue
+		}
+		if ok && event.Type != c.wantT {
+			t.Errorf("%s: type = %v, want %v", c.name, event.Type, c.wantT)
+		}
+	}
+}
+
+// fakeEventsClient implements just enough of dockerclient.Client to drive
+// eventHub; every other method panics via the embedded nil interface if
+// ever called.
+type fakeEventsClient struct {
+	dockerclient.Client
+	stopCalls int
+}
+
+func (f *fakeEventsClient) StartMonitorEvents(cb dockerclient.Callback, ec chan error, args ...interface{}) {
+}
+
+func (f *fakeEventsClient) StopAllMonitorEvents() {
+	f.stopCalls++
+}
+
+func TestEventHubReleasesOnceLastSubscriberLeaves(t *testing.T) {
+	client := &fakeEventsClient{}
+	ctx := &Context{Client: client}
+
+	hub := hubFor(ctx)
+
+	_, cancel1 := hub.subscribe("c1")
+	_, cancel2 := hub.subscribe("c2")
+
+	cancel1()
+	if client.stopCalls != 0 {
+		t.Fatalf("expected the hub to stay alive with one subscriber left, got %d stop calls", client.stopCalls)
+	}
+
+	eventHubsMu.Lock()
+	_, stillTracked := eventHubs[ctx]
+	eventHubsMu.Unlock()
+	if !stillTracked {
+		t.Fatalf("expected hub to still be tracked while a subscriber remains")
+	}
+
+	cancel2()
+	if client.stopCalls != 1 {
+		t.Fatalf("expected StopAllMonitorEvents once the last subscriber left, got %d calls", client.stopCalls)
+	}
+
+	eventHubsMu.Lock()
+	_, stillTracked = eventHubs[ctx]
+	eventHubsMu.Unlock()
+	if stillTracked {
+		t.Fatalf("expected hub to be removed from eventHubs after release")
+	}
+}
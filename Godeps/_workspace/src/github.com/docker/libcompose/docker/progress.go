@@ -0,0 +1,156 @@
+package docker
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	"github.com/docker/libcompose/logger"
+)
+
+// ProgressEvent is a single status update from a pull/build JSON stream,
+// e.g. {"status":"Downloading","progressDetail":{"current":1024,"total":4096},"id":"a3ed95caeb02"}.
+type ProgressEvent struct {
+	Status         string `json:"status"`
+	ID             string `json:"id"`
+	ProgressDetail struct {
+		Current int64 `json:"current"`
+		Total   int64 `json:"total"`
+	} `json:"progressDetail"`
+}
+
+func (e ProgressEvent) line() string {
+	switch {
+	case e.ID == "":
+		return e.Status
+	case e.ProgressDetail.Total > 0:
+		return fmt.Sprintf("%s: %s %d/%d", e.ID, e.Status, e.ProgressDetail.Current, e.ProgressDetail.Total)
+	default:
+		return fmt.Sprintf("%s: %s", e.ID, e.Status)
+	}
+}
+
+// ttyLogger is implemented by logger.Logger implementations that can
+// report whether their underlying output is an interactive terminal.
+type ttyLogger interface {
+	IsTerminal() bool
+}
+
+// ProgressWriter decodes a Docker JSON progress stream and forwards it to
+// a logger.Logger. When the logger is a TTY it collapses each layer's
+// repeated updates into a single, redrawn line; otherwise it emits one
+// line per distinct status change, same as a non-interactive docker pull.
+type ProgressWriter struct {
+	l       logger.Logger
+	tty     bool
+	onEvent func(ProgressEvent)
+
+	order      []string
+	index      map[string]int
+	lastLine   map[string]string
+	lastStatus map[string]string
+
+	buf []byte // bytes read but not yet split into a full line
+}
+
+// NewProgressWriter builds a ProgressWriter that writes formatted progress
+// to l. onEvent, if non-nil, is called with every decoded event so callers
+// embedding libcompose can observe raw pull progress programmatically.
+func NewProgressWriter(l logger.Logger, tty bool, onEvent func(ProgressEvent)) *ProgressWriter {
+	return &ProgressWriter{
+		l:          l,
+		tty:        tty,
+		onEvent:    onEvent,
+		index:      map[string]int{},
+		lastLine:   map[string]string{},
+		lastStatus: map[string]string{},
+	}
+}
+
+// Write decodes as many complete JSON lines as p and any previously
+// buffered bytes contain, carrying an undigested trailing line over to the
+// next call rather than dropping it: the stream is fed in directly off the
+// network, so a status line can straddle two Write calls.
+func (w *ProgressWriter) Write(p []byte) (int, error) {
+	w.buf = append(w.buf, p...)
+
+	for {
+		i := bytes.IndexByte(w.buf, '\n')
+		if i < 0 {
+			break
+		}
+
+		line := w.buf[:i]
+		w.buf = w.buf[i+1:]
+		w.processLine(line)
+	}
+
+	return len(p), nil
+}
+
+// Close flushes any trailing, non-newline-terminated line left in the
+// buffer once the underlying pull stream has ended, so the final progress
+// update isn't silently dropped the way it would be with no flush step.
+func (w *ProgressWriter) Close() error {
+	if len(w.buf) > 0 {
+		w.processLine(w.buf)
+		w.buf = nil
+	}
+
+	return nil
+}
+
+func (w *ProgressWriter) processLine(raw []byte) {
+	line := bytes.TrimSpace(raw)
+	if len(line) == 0 {
+		return
+	}
+
+	var event ProgressEvent
+	if err := json.Unmarshal(line, &event); err != nil {
+		return
+	}
+
+	if w.onEvent != nil {
+		w.onEvent(event)
+	}
+
+	w.emit(event)
+}
+
+func (w *ProgressWriter) emit(event ProgressEvent) {
+	key := event.ID
+	if key == "" {
+		key = event.Status
+	}
+	line := event.line()
+
+	if !w.tty {
+		if prev, seen := w.lastStatus[key]; seen && prev == event.Status {
+			return
+		}
+		w.lastStatus[key] = event.Status
+		w.l.Out([]byte(line + "\n"))
+		return
+	}
+
+	idx, seen := w.index[key]
+	w.lastLine[key] = line
+	if !seen {
+		idx = len(w.order)
+		w.index[key] = idx
+		w.order = append(w.order, key)
+		w.l.Out([]byte(line + "\n"))
+		return
+	}
+
+	// Move the cursor up to this layer's line, redraw it, then back down
+	// so the next write lands after the last known line again.
+	rowsUp := len(w.order) - idx
+	w.l.Out([]byte(fmt.Sprintf("\033[%dA\033[2K%s\033[%dB\r", rowsUp, line, rowsUp)))
+}
+
+func loggerIsTTY(l logger.Logger) bool {
+	t, ok := l.(ttyLogger)
+	return ok && t.IsTerminal()
+}
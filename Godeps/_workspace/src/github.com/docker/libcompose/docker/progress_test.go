@@ -0,0 +1,92 @@
+package docker
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+type fakeLogger struct {
+	lines []string
+}
+
+func (f *fakeLogger) Out(p []byte) { f.lines = append(f.lines, string(p)) }
+func (f *fakeLogger) Err(p []byte) { f.lines = append(f.lines, string(p)) }
+
+func TestProgressEventLine(t *testing.T) {
+	cases := []struct {
+		name string
+		json string
+		want string
+	}{
+		{"status only", `{"status":"Pulling fs layer"}`, "Pulling fs layer"},
+		{"id no progress", `{"status":"Waiting","id":"abc123"}`, "abc123: Waiting"},
+		{"id with progress", `{"status":"Downloading","id":"abc123","progressDetail":{"current":10,"total":100}}`, "abc123: Downloading 10/100"},
+	}
+
+	for _, c := range cases {
+		var event ProgressEvent
+		if err := json.Unmarshal([]byte(c.json), &event); err != nil {
+			t.Fatalf("%s: unmarshal: %v", c.name, err)
+		}
+		if got := event.line(); got != c.want {
+			t.Errorf("%s: line() = %q, want %q", c.name, got, c.want)
+		}
+	}
+}
+
+func TestProgressWriterCarriesPartialLineAcrossWrites(t *testing.T) {
+	l := &fakeLogger{}
+	w := NewProgressWriter(l, false, nil)
+
+	full := `{"status":"Downloading","id":"layer1","progressDetail":{"current":1,"total":2}}` + "\n"
+	mid := len(full) / 2
+
+	if _, err := w.Write([]byte(full[:mid])); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	if len(l.lines) != 0 {
+		t.Fatalf("expected nothing emitted before the newline arrives, got %v", l.lines)
+	}
+
+	if _, err := w.Write([]byte(full[mid:])); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	if len(l.lines) != 1 {
+		t.Fatalf("expected exactly one emitted line once the split JSON line completed, got %v", l.lines)
+	}
+}
+
+func TestProgressWriterCloseFlushesUnterminatedLine(t *testing.T) {
+	l := &fakeLogger{}
+	w := NewProgressWriter(l, false, nil)
+
+	// No trailing newline: the stream closed mid-line, as the final
+	// status update in a pull often isn't newline-terminated.
+	if _, err := w.Write([]byte(`{"status":"Pull complete","id":"layer1"}`)); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	if len(l.lines) != 0 {
+		t.Fatalf("expected nothing emitted before Close, got %v", l.lines)
+	}
+
+	if err := w.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+	if len(l.lines) != 1 {
+		t.Fatalf("expected Close to flush the trailing line, got %v", l.lines)
+	}
+}
+
+func TestProgressWriterInvokesOnEvent(t *testing.T) {
+	var got []ProgressEvent
+	l := &fakeLogger{}
+	w := NewProgressWriter(l, false, func(e ProgressEvent) { got = append(got, e) })
+
+	if _, err := w.Write([]byte(`{"status":"Pull complete","id":"layer1"}` + "\n")); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+
+	if len(got) != 1 || got[0].ID != "layer1" {
+		t.Fatalf("onEvent = %#v, want one event for layer1", got)
+	}
+}
@@ -0,0 +1,87 @@
+package docker
+
+import (
+	"sync"
+
+	"github.com/samalba/dockerclient"
+	"golang.org/x/net/context"
+)
+
+// Project is the docker-specific counterpart to project.Project: a
+// Context together with enough bookkeeping to act on every container it
+// manages as a whole, rather than one Service/Container at a time.
+type Project struct {
+	context *Context
+}
+
+// NewProject wraps ctx so its containers can be discovered and watched as
+// a whole, e.g. via Events.
+func NewProject(ctx *Context) *Project {
+	return &Project{context: ctx}
+}
+
+// containers lists every container labeled as belonging to this project,
+// the same PROJECT label createContainer sets.
+func (p *Project) containers() ([]dockerclient.Container, error) {
+	all, err := p.context.Client.ListContainers(true, false, "")
+	if err != nil {
+		return nil, err
+	}
+
+	var matched []dockerclient.Container
+	for _, container := range all {
+		if container.Labels[PROJECT.Str()] == p.context.Project.Name {
+			matched = append(matched, container)
+		}
+	}
+
+	return matched, nil
+}
+
+// Events discovers every container belonging to this project (by the
+// PROJECT label) and aggregates their Events() streams into a single
+// channel, so a caller can watch an entire project without first
+// enumerating its containers and services itself.
+func (p *Project) Events(ctx context.Context) (<-chan Event, error) {
+	containers, err := p.containers()
+	if err != nil {
+		return nil, err
+	}
+
+	hub := hubFor(p.context)
+	out := make(chan Event)
+	var wg sync.WaitGroup
+
+	for _, container := range containers {
+		ch, cancel := hub.subscribe(container.Id)
+
+		wg.Add(1)
+		go func(ch chan Event, cancel func()) {
+			defer wg.Done()
+			defer cancel()
+
+			for {
+				select {
+				case event, ok := <-ch:
+					if !ok {
+						return
+					}
+					select {
+					case out <- event:
+					case <-ctx.Done():
+						return
+					}
+				case <-ctx.Done():
+					return
+				}
+			}
+		}(ch, cancel)
+	}
+
+	go func() {
+		wg.Wait()
+		close(out)
+	}()
+
+	return out, nil
+}
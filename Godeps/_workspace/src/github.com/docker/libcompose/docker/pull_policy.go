@@ -0,0 +1,141 @@
+package docker
+
+import (
+	"fmt"
+
+	"github.com/docker/docker/graph/tags"
+	"github.com/docker/docker/pkg/parsers"
+	"github.com/docker/docker/registry"
+)
+
+// PullPolicy controls when Container.Create/Up pull an image rather than
+// reusing whatever is already present on the daemon.
+type PullPolicy string
+
+const (
+	// PullPolicyMissing pulls only when the image can't be found locally.
+	// This is the default and matches the historical behavior of pulling
+	// after a failed create.
+	PullPolicyMissing PullPolicy = "missing"
+	// PullPolicyAlways pulls on every Up, even if the image is already
+	// present.
+	PullPolicyAlways PullPolicy = "always"
+	// PullPolicyNever never pulls; Up fails fast if the image is absent.
+	PullPolicyNever PullPolicy = "never"
+	// PullPolicyIfDigestChanged pulls only when the remote manifest
+	// digest matches none of the local image's RepoDigests.
+	PullPolicyIfDigestChanged PullPolicy = "if-digest-changed"
+)
+
+// ErrImageNotPresent is returned when PullPolicyNever is in effect and the
+// image a service needs is not already present on the daemon.
+type ErrImageNotPresent struct {
+	Image string
+}
+
+func (e *ErrImageNotPresent) Error() string {
+	return fmt.Sprintf("image %s is not present and pull policy is %q", e.Image, PullPolicyNever)
+}
+
+// ensureImage makes image present locally according to the context's
+// PullPolicy, pulling it if (and only if) the policy calls for it.
+func (c *Container) ensureImage(image string) error {
+	policy := c.service.context.PullPolicy
+
+	var present, changed bool
+	if policy == PullPolicyIfDigestChanged {
+		var err error
+		if changed, err = c.digestChanged(image); err != nil {
+			return err
+		}
+	} else {
+		_, err := c.service.context.Client.InspectImage(image)
+		present = err == nil
+	}
+
+	shouldPull, err := pullDecision(policy, image, present, changed)
+	if err != nil {
+		return err
+	}
+	if shouldPull {
+		return c.pull(image)
+	}
+	return nil
+}
+
+// pullDecision applies PullPolicy's rules given whether image is already
+// present locally and, for PullPolicyIfDigestChanged, whether the remote
+// digest differs from the local one, deciding whether ensureImage should
+// pull. It's kept separate from ensureImage so the policy logic itself is
+// testable without a live Client.
+func pullDecision(policy PullPolicy, image string, present, digestChanged bool) (bool, error) {
+	switch policy {
+	case PullPolicyAlways:
+		return true, nil
+	case PullPolicyNever:
+		if !present {
+			return false, &ErrImageNotPresent{Image: image}
+		}
+		return false, nil
+	case PullPolicyIfDigestChanged:
+		return digestChanged, nil
+	default: // PullPolicyMissing, or unset
+		return !present, nil
+	}
+}
+
+// digestChanged reports whether the registry's current manifest digest for
+// image differs from every digest already recorded against the local copy.
+func (c *Container) digestChanged(image string) (bool, error) {
+	localImage, err := c.service.context.Client.InspectImage(image)
+	if err != nil {
+		// No local copy at all, so there's nothing to compare against.
+		return true, nil
+	}
+
+	remoteDigest, err := c.remoteDigest(image)
+	if err != nil {
+		return false, err
+	}
+
+	for _, digest := range localImage.RepoDigests {
+		if digest == remoteDigest {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+// remoteDigest resolves the manifest digest the registry currently serves
+// for image, without pulling the image itself.
+func (c *Container) remoteDigest(image string) (string, error) {
+	taglessRemote, tag := parsers.ParseRepositoryTag(image)
+	if tag == "" {
+		tag = tags.DEFAULTTAG
+	}
+
+	repoInfo, err := registry.ParseRepositoryInfo(taglessRemote)
+	if err != nil {
+		return "", err
+	}
+
+	authConfig := c.resolveAuthConfig(repoInfo)
+
+	endpoint, err := registry.NewEndpoint(repoInfo.Index, nil)
+	if err != nil {
+		return "", err
+	}
+
+	session, err := registry.NewSession(&authConfig, nil, endpoint, true)
+	if err != nil {
+		return "", err
+	}
+
+	_, _, digest, err := session.GetRemoteManifest(taglessRemote, tag)
+	if err != nil {
+		return "", err
+	}
+
+	return digest, nil
+}
@@ -0,0 +1,41 @@
+package docker
+
+import "testing"
+
+func TestPullDecision(t *testing.T) {
+	cases := []struct {
+		name          string
+		policy        PullPolicy
+		present       bool
+		digestChanged bool
+		wantPull      bool
+		wantErr       bool
+	}{
+		{"always pulls even when present", PullPolicyAlways, true, false, true, false},
+		{"always pulls when absent", PullPolicyAlways, false, false, true, false},
+		{"never errors when absent", PullPolicyNever, false, false, false, true},
+		{"never no-ops when present", PullPolicyNever, true, false, false, false},
+		{"missing pulls when absent", PullPolicyMissing, false, false, true, false},
+		{"missing no-ops when present", PullPolicyMissing, true, false, false, false},
+		{"zero value behaves like missing, absent", "", false, false, true, false},
+		{"zero value behaves like missing, present", "", true, false, false, false},
+		{"digest-changed pulls when changed", PullPolicyIfDigestChanged, true, true, true, false},
+		{"digest-changed no-ops when unchanged", PullPolicyIfDigestChanged, true, false, false, false},
+	}
+
+	for _, c := range cases {
+		pull, err := pullDecision(c.policy, "repo/image", c.present, c.digestChanged)
+		if (err != nil) != c.wantErr {
+			t.Errorf("%s: err = %v, wantErr %v", c.name, err, c.wantErr)
+			continue
+		}
+		if c.wantErr {
+			if _, ok := err.(*ErrImageNotPresent); !ok {
+				t.Errorf("%s: err = %#v, want *ErrImageNotPresent", c.name, err)
+			}
+		}
+		if pull != c.wantPull {
+			t.Errorf("%s: pull = %v, want %v", c.name, pull, c.wantPull)
+		}
+	}
+}
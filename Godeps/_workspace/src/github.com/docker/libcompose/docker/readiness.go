@@ -0,0 +1,98 @@
+package docker
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/samalba/dockerclient"
+)
+
+// DependencyReadiness controls how long populateAdditionalHostConfig waits
+// on a dependent service's container before wiring links/IPC/net
+// namespaces to it.
+type DependencyReadiness string
+
+const (
+	// DependencyReadinessNone wires dependencies immediately, matching
+	// the historical "first container, whatever its state" behavior.
+	DependencyReadinessNone DependencyReadiness = "none"
+	// DependencyReadinessStarted waits until the dependency's container
+	// is running.
+	DependencyReadinessStarted DependencyReadiness = "started"
+	// DependencyReadinessHealthy additionally waits for a HEALTHCHECK,
+	// when the image declares one, to report "healthy".
+	DependencyReadinessHealthy DependencyReadiness = "healthy"
+)
+
+const (
+	defaultReadinessTimeout  = 2 * time.Minute
+	initialReadinessInterval = 250 * time.Millisecond
+	maxReadinessInterval     = 5 * time.Second
+)
+
+// DependencyNotReadyError is returned when a dependent service's container
+// fails to reach the configured DependencyReadiness before its timeout,
+// so Up can report which upstream service blocked startup.
+type DependencyNotReadyError struct {
+	Service string
+	Want    DependencyReadiness
+	Cause   error
+}
+
+func (e *DependencyNotReadyError) Error() string {
+	if e.Cause != nil {
+		return fmt.Sprintf("dependency %s did not become %s: %v", e.Service, e.Want, e.Cause)
+	}
+	return fmt.Sprintf("timed out waiting for dependency %s to become %s", e.Service, e.Want)
+}
+
+// waitForReady blocks until containerID (belonging to serviceName)
+// satisfies the context's configured DependencyReadiness, polling
+// InspectContainer with exponential backoff up to DependencyReadinessTimeout.
+func (c *Container) waitForReady(serviceName, containerID string) error {
+	mode := c.service.context.DependencyReadiness
+	if mode == "" || mode == DependencyReadinessNone {
+		return nil
+	}
+
+	timeout := c.service.context.DependencyReadinessTimeout
+	if timeout == 0 {
+		timeout = defaultReadinessTimeout
+	}
+
+	deadline := time.Now().Add(timeout)
+	interval := initialReadinessInterval
+
+	for {
+		info, err := c.service.context.Client.InspectContainer(containerID)
+		if err != nil {
+			return &DependencyNotReadyError{Service: serviceName, Want: mode, Cause: err}
+		}
+
+		if dependencyReady(info, mode) {
+			return nil
+		}
+
+		if time.Now().After(deadline) {
+			return &DependencyNotReadyError{Service: serviceName, Want: mode}
+		}
+
+		time.Sleep(interval)
+		interval *= 2
+		if interval > maxReadinessInterval {
+			interval = maxReadinessInterval
+		}
+	}
+}
+
+func dependencyReady(info *dockerclient.ContainerInfo, mode DependencyReadiness) bool {
+	if !info.State.Running {
+		return false
+	}
+
+	if mode == DependencyReadinessHealthy && info.State.Health != nil {
+		return info.State.Health.Status == "healthy"
+	}
+
+	return true
+}
@@ -0,0 +1,42 @@
+package docker
+
+import (
+	"testing"
+
+	"github.com/samalba/dockerclient"
+)
+
+func TestDependencyReady(t *testing.T) {
+	notRunning := &dockerclient.ContainerInfo{}
+
+	runningNoHealthcheck := &dockerclient.ContainerInfo{}
+	runningNoHealthcheck.State.Running = true
+
+	runningHealthy := &dockerclient.ContainerInfo{}
+	runningHealthy.State.Running = true
+	runningHealthy.State.Health = &dockerclient.Health{Status: "healthy"}
+
+	runningUnhealthy := &dockerclient.ContainerInfo{}
+	runningUnhealthy.State.Running = true
+	runningUnhealthy.State.Health = &dockerclient.Health{Status: "unhealthy"}
+
+	cases := []struct {
+		name string
+		info *dockerclient.ContainerInfo
+		mode DependencyReadiness
+		want bool
+	}{
+		{"started: not running", notRunning, DependencyReadinessStarted, false},
+		{"started: running", runningNoHealthcheck, DependencyReadinessStarted, true},
+		{"healthy: not running", notRunning, DependencyReadinessHealthy, false},
+		{"healthy: running, no HEALTHCHECK declared", runningNoHealthcheck, DependencyReadinessHealthy, true},
+		{"healthy: running and healthy", runningHealthy, DependencyReadinessHealthy, true},
+		{"healthy: running but unhealthy", runningUnhealthy, DependencyReadinessHealthy, false},
+	}
+
+	for _, c := range cases {
+		if got := dependencyReady(c.info, c.mode); got != c.want {
+			t.Errorf("%s: dependencyReady() = %v, want %v", c.name, got, c.want)
+		}
+	}
+}